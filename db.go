@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	hclog "github.com/hashicorp/go-hclog"
+
+	"github.com/nicholasjackson/env"
+)
+
+var dbConnectMaxWait = env.Duration("DB_CONNECT_MAX_WAIT", false, 30*time.Second, "Maximum total time to retry the initial database connection before giving up")
+var dbMaxOpenConns = env.Int("DB_MAX_OPEN_CONNS", false, 25, "Maximum number of open connections to the database")
+var dbMaxIdleConns = env.Int("DB_MAX_IDLE_CONNS", false, 25, "Maximum number of idle connections to keep in the pool")
+var dbConnMaxLifetime = env.Duration("DB_CONN_MAX_LIFETIME", false, 5*time.Minute, "Maximum amount of time a connection may be reused")
+
+const dbPingTimeout = 1 * time.Second
+
+// dbState tracks the outcome of the most recent connection attempt so it can
+// be surfaced through the readiness endpoint.
+type dbState struct {
+	mu      sync.RWMutex
+	lastErr error
+}
+
+func (s *dbState) set(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastErr = err
+}
+
+func (s *dbState) get() error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.lastErr
+}
+
+// connectWithRetry opens the database connection and retries Ping with
+// exponential backoff, capped at maxWait in total, rather than letting the
+// process start believing it is healthy when no database is reachable.
+func connectWithRetry(connectionString string, maxWait time.Duration) (*sql.DB, error) {
+	db, err := sql.Open("postgres", connectionString)
+	if err != nil {
+		return nil, err
+	}
+
+	db.SetMaxOpenConns(*dbMaxOpenConns)
+	db.SetMaxIdleConns(*dbMaxIdleConns)
+	db.SetConnMaxLifetime(*dbConnMaxLifetime)
+
+	deadline := time.Now().Add(maxWait)
+	backoff := 250 * time.Millisecond
+	const maxBackoff = 5 * time.Second
+
+	var pingErr error
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), dbPingTimeout)
+		pingErr = db.PingContext(ctx)
+		cancel()
+
+		if pingErr == nil {
+			return db, nil
+		}
+
+		if time.Now().Add(backoff).After(deadline) {
+			return db, fmt.Errorf("giving up connecting to database after %s: %w", maxWait, pingErr)
+		}
+
+		hclog.Default().Warn("Database not reachable yet, retrying", "error", pingErr, "backoff", backoff)
+		time.Sleep(backoff)
+
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+// isReady pings the database with a short timeout and reports whether the
+// service can currently serve traffic.
+func (a *App) isReady(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, dbPingTimeout)
+	defer cancel()
+
+	err := a.DB.PingContext(ctx)
+	a.dbState.set(err)
+
+	return err
+}
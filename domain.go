@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// buildCustomersResponse fetches customers from the database, fans out to any
+// configured upstreams, and assembles the Response shared by both the HTTP
+// and gRPC surfaces so timing fields, error mapping, and upstream chaining
+// stay identical regardless of transport.
+func buildCustomersResponse(ctx context.Context, db *sql.DB, headers http.Header) *Response {
+	resp := &Response{Name: *name}
+
+	ts := time.Now()
+	resp.StartTime = ts.Format(timeFormat)
+
+	customers, err := getCustomers(db)
+
+	te := time.Now()
+	resp.EndTime = te.Format(timeFormat)
+	resp.Duration = te.Sub(ts).String()
+
+	if err != nil {
+		resp.Body = json.RawMessage(fmt.Sprintf(`"%s"`, err.Error()))
+		resp.Code = http.StatusInternalServerError
+		resp.Error = err.Error()
+		return resp
+	}
+
+	message := fmt.Sprintf("Hello %s", strings.Join(customers, " "))
+
+	resp.Body = json.RawMessage(fmt.Sprintf(`"%s"`, message))
+	resp.Code = http.StatusOK
+
+	if uris := parseUpstreamURIs(*upstreamURIs); len(uris) > 0 {
+		resp.UpstreamCalls = callUpstreams(ctx, uris, *upstreamWorkers, *upstreamTimeout, headers)
+	}
+
+	return resp
+}
+
+// buildRowsResponse runs the configured /rows query and assembles the
+// Response carrying the structured results, mirroring the timing and error
+// handling conventions of buildCustomersResponse.
+func buildRowsResponse(ctx context.Context, db *sql.DB, args []interface{}) *Response {
+	resp := &Response{Name: *name}
+
+	ts := time.Now()
+	resp.StartTime = ts.Format(timeFormat)
+
+	rows, err := queryRows(ctx, db, args)
+
+	te := time.Now()
+	resp.EndTime = te.Format(timeFormat)
+	resp.Duration = te.Sub(ts).String()
+
+	if err != nil {
+		resp.Code = http.StatusInternalServerError
+		resp.Error = err.Error()
+		return resp
+	}
+
+	body, err := json.Marshal(rows)
+	if err != nil {
+		resp.Code = http.StatusInternalServerError
+		resp.Error = err.Error()
+		return resp
+	}
+
+	resp.Body = json.RawMessage(body)
+	resp.Code = http.StatusOK
+
+	return resp
+}
+
+// buildHealthResponse assembles the Response returned by the gRPC Health and
+// Ready RPCs and the JSON-RPC health.check and ready.check methods. The HTTP
+// /health and /ready routes write their own bodies and do not use it.
+func buildHealthResponse() *Response {
+	return &Response{
+		Name: *name,
+		Body: json.RawMessage(`"OK"`),
+		Code: http.StatusOK,
+	}
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	hclog "github.com/hashicorp/go-hclog"
+	"github.com/lib/pq"
+
+	"github.com/nicholasjackson/env"
+)
+
+var notifyChannel = env.String("NOTIFY_CHANNEL", false, "customers_changed", "PostgreSQL channel to LISTEN on for the /events endpoint")
+
+const (
+	listenerMinReconnectInterval = 2 * time.Second
+	listenerMaxReconnectInterval = 20 * time.Second
+	eventsHeartbeatInterval      = 15 * time.Second
+)
+
+// events streams PostgreSQL LISTEN/NOTIFY payloads on notifyChannel to the
+// client as Server-Sent Events, each frame a Response JSON with the
+// notification payload in Body. Install a trigger like the following on the
+// table you want to watch to start emitting notifications:
+//
+//	CREATE OR REPLACE FUNCTION notify_customers_changed() RETURNS trigger AS $$
+//	BEGIN
+//	  PERFORM pg_notify('customers_changed', row_to_json(NEW)::text);
+//	  RETURN NEW;
+//	END;
+//	$$ LANGUAGE plpgsql;
+//
+//	CREATE TRIGGER customers_changed
+//	  AFTER INSERT OR UPDATE ON customers
+//	  FOR EACH ROW EXECUTE FUNCTION notify_customers_changed();
+func (a *App) events(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		respondWithError(w, http.StatusInternalServerError, "streaming unsupported")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	listener := pq.NewListener(a.dbConnectionString, listenerMinReconnectInterval, listenerMaxReconnectInterval, func(ev pq.ListenerEventType, err error) {
+		if err != nil {
+			hclog.Default().Error("Listener event", "error", err)
+		}
+	})
+	defer listener.Close()
+
+	if err := listener.Listen(*notifyChannel); err != nil {
+		hclog.Default().Error("Unable to listen on channel", "channel", *notifyChannel, "error", err)
+		respondWithError(w, http.StatusInternalServerError, fmt.Sprintf("unable to listen on channel %s", *notifyChannel))
+		return
+	}
+
+	hclog.Default().Info("Client connected to /events", "channel", *notifyChannel)
+
+	heartbeat := time.NewTicker(eventsHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	ctx := r.Context()
+
+	for {
+		select {
+		case <-ctx.Done():
+			hclog.Default().Info("Client disconnected from /events")
+			return
+
+		case n := <-listener.Notify:
+			if n == nil {
+				// The connection was lost; Listener reconnects and re-subscribes
+				// to every channel passed to Listen on its own, so there is
+				// nothing to redo here.
+				continue
+			}
+
+			if err := writeEventResponse(w, n); err != nil {
+				hclog.Default().Error("Unable to write event", "error", err)
+				return
+			}
+
+			flusher.Flush()
+
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// writeEventResponse frames a single pq.Notification as an SSE "data:" line
+// carrying a Response JSON payload.
+func writeEventResponse(w http.ResponseWriter, n *pq.Notification) error {
+	resp := &Response{
+		Name: *name,
+		Body: json.RawMessage(fmt.Sprintf("%q", n.Extra)),
+		Code: http.StatusOK,
+	}
+
+	payload, err := json.Marshal(resp)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "data: %s\n\n", payload)
+	return err
+}
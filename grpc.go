@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"time"
+
+	hclog "github.com/hashicorp/go-hclog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/reflection"
+
+	"github.com/joatmon08/fake-service-db/proto"
+	"github.com/nicholasjackson/env"
+)
+
+var grpcListenAddress = env.String("GRPC_LISTEN_ADDR", false, "", "IP address and port to bind the gRPC server to, leave empty to disable")
+
+// grpcServer adapts the domain functions shared with the HTTP handlers to the
+// proto.FakeServiceDBServer interface.
+type grpcServer struct {
+	proto.UnimplementedFakeServiceDBServer
+
+	app *App
+}
+
+func (s *grpcServer) GetCustomers(ctx context.Context, req *proto.GetCustomersRequest) (*proto.Response, error) {
+	resp := buildCustomersResponse(ctx, s.app.DB, headerFromMap(req.GetHeaders()))
+	return responseToProto(resp), nil
+}
+
+func (s *grpcServer) Health(ctx context.Context, _ *proto.Empty) (*proto.Response, error) {
+	return responseToProto(buildHealthResponse()), nil
+}
+
+func (s *grpcServer) Ready(ctx context.Context, _ *proto.Empty) (*proto.Response, error) {
+	resp := buildHealthResponse()
+
+	if err := s.app.isReady(ctx); err != nil {
+		resp.Code = http.StatusServiceUnavailable
+		resp.Error = err.Error()
+	}
+
+	return responseToProto(resp), nil
+}
+
+// responseToProto converts the shared Response into its gRPC wire representation.
+func responseToProto(r *Response) *proto.Response {
+	if r == nil {
+		return nil
+	}
+
+	out := &proto.Response{
+		Name:        r.Name,
+		Uri:         r.URI,
+		Type:        r.Type,
+		IpAddresses: r.IPAddresses,
+		Path:        r.Path,
+		StartTime:   r.StartTime,
+		EndTime:     r.EndTime,
+		Duration:    r.Duration,
+		Headers:     r.Headers,
+		Cookies:     r.Cookies,
+		Body:        []byte(r.Body),
+		Code:        int32(r.Code),
+		Error:       r.Error,
+	}
+
+	if len(r.UpstreamCalls) > 0 {
+		out.UpstreamCalls = make(map[string]*proto.Response, len(r.UpstreamCalls))
+		for uri, call := range r.UpstreamCalls {
+			call := call
+			out.UpstreamCalls[uri] = responseToProto(&call)
+		}
+	}
+
+	return out
+}
+
+// headerFromMap adapts a plain map of header values, as received over gRPC,
+// into the http.Header shape used by the upstream call chaining logic.
+func headerFromMap(m map[string]string) http.Header {
+	h := http.Header{}
+	for k, v := range m {
+		h.Set(k, v)
+	}
+	return h
+}
+
+// startGRPCServer starts the gRPC listener when GRPC_LISTEN_ADDR is set,
+// blocking until the listener fails or the server is stopped.
+func startGRPCServer(a *App) {
+	if *grpcListenAddress == "" {
+		return
+	}
+
+	lis, err := net.Listen("tcp", *grpcListenAddress)
+	if err != nil {
+		hclog.Default().Error("Unable to start gRPC listener", "error", err)
+		return
+	}
+
+	s := grpc.NewServer(
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			Time:    30 * time.Second,
+			Timeout: 10 * time.Second,
+		}),
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             15 * time.Second,
+			PermitWithoutStream: true,
+		}),
+	)
+
+	proto.RegisterFakeServiceDBServer(s, &grpcServer{app: a})
+	reflection.Register(s)
+
+	hclog.Default().Info("Starting gRPC server", "address", *grpcListenAddress)
+
+	if err := s.Serve(lis); err != nil {
+		hclog.Default().Error("gRPC server exited", "error", err)
+	}
+}
@@ -6,8 +6,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"strings"
-	"time"
 
 	"github.com/gorilla/mux"
 	hclog "github.com/hashicorp/go-hclog"
@@ -33,6 +31,9 @@ var databaseName = env.String("DATABASE_NAME", false, "", "Name of database for
 type App struct {
 	Router *mux.Router
 	DB     *sql.DB
+
+	dbConnectionString string
+	dbState            dbState
 }
 
 func (a *App) Initialize(user, password, host, dbname string, port int) {
@@ -40,10 +41,15 @@ func (a *App) Initialize(user, password, host, dbname string, port int) {
 	connectionString :=
 		fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=disable", user, password, host, port, dbname)
 
-	var err error
-	a.DB, err = sql.Open("postgres", connectionString)
+	a.dbConnectionString = connectionString
+
+	db, err := connectWithRetry(connectionString, *dbConnectMaxWait)
+	a.DB = db
+	a.dbState.set(err)
 	if err != nil {
-		hclog.Default().Error("Cannot connect to database, %s", err)
+		hclog.Default().Error("Cannot connect to database", "error", err)
+	} else if err := validateQueryConfig(db); err != nil {
+		hclog.Default().Error("Invalid query configuration", "error", err)
 	}
 
 	a.Router = mux.NewRouter()
@@ -66,13 +72,18 @@ func main() {
 
 	a.initializeRoutes()
 
+	go startGRPCServer(&a)
+
 	a.Run(*listenAddress)
 }
 
 func (a *App) initializeRoutes() {
 	a.Router.HandleFunc("/", a.getCustomers).Methods("GET")
 	a.Router.HandleFunc("/health", a.health).Methods("GET")
-	a.Router.HandleFunc("/ready", a.health).Methods("GET")
+	a.Router.HandleFunc("/events", a.events).Methods("GET")
+	a.Router.HandleFunc("/ready", a.ready).Methods("GET")
+	a.Router.HandleFunc("/rows", a.getRows).Methods("GET")
+	a.Router.HandleFunc("/rpc", a.rpc).Methods("POST")
 }
 
 func respondWithError(w http.ResponseWriter, code int, message string) {
@@ -87,34 +98,36 @@ func respondWithJSON(w http.ResponseWriter, code int, payload interface{}) {
 	w.Write(response)
 }
 
+// health reports process liveness and always returns 200 while the process
+// is up, regardless of database state.
 func (a *App) health(w http.ResponseWriter, r *http.Request) {
 	respondWithJSON(w, http.StatusOK, "OK")
 }
 
-func (a *App) getCustomers(w http.ResponseWriter, r *http.Request) {
-	resp := &Response{
-		Name: *name,
-	}
-
-	ts := time.Now()
-	te := time.Now()
-	resp.StartTime = ts.Format(timeFormat)
-	resp.EndTime = te.Format(timeFormat)
-	resp.Duration = te.Sub(ts).String()
-
-	customers, err := getCustomers(a.DB)
-	if err != nil {
-		resp.Body = json.RawMessage(fmt.Sprintf(`"%s"`, err.Error()))
-		resp.Code = http.StatusInternalServerError
-		respondWithJSON(w, http.StatusInternalServerError, resp)
+// ready reports whether the service can currently reach its database,
+// returning 503 until a ping succeeds.
+func (a *App) ready(w http.ResponseWriter, r *http.Request) {
+	if err := a.isReady(r.Context()); err != nil {
+		respondWithJSON(w, http.StatusServiceUnavailable, map[string]string{
+			"status": "not ready",
+			"error":  err.Error(),
+		})
 		return
 	}
 
-	message := fmt.Sprintf("Hello %s", strings.Join(customers, " "))
+	respondWithJSON(w, http.StatusOK, map[string]string{"status": "ready"})
+}
+
+func (a *App) getCustomers(w http.ResponseWriter, r *http.Request) {
+	resp := buildCustomersResponse(r.Context(), a.DB, r.Header)
+	respondWithJSON(w, resp.Code, resp)
+}
 
-	resp.Body = json.RawMessage(fmt.Sprintf(`"%s"`, message))
-	resp.Code = http.StatusOK
-	respondWithJSON(w, http.StatusOK, resp)
+// getRows serves the configurable query subsystem, returning rows as
+// structured JSON rather than the "/" route's greeting string.
+func (a *App) getRows(w http.ResponseWriter, r *http.Request) {
+	resp := buildRowsResponse(r.Context(), a.DB, queryArgsFromRequest(r))
+	respondWithJSON(w, resp.Code, resp)
 }
 
 type customer struct {
@@ -0,0 +1,55 @@
+package proto
+
+import (
+	"fmt"
+
+	"google.golang.org/grpc/encoding"
+)
+
+// marshaler and unmarshaler are satisfied by every message type in this
+// package; grpcCodec uses them directly instead of the reflection-based
+// google.golang.org/protobuf codec, which these hand-maintained types don't
+// implement (see fake_service_db.pb.go).
+type marshaler interface {
+	Marshal() ([]byte, error)
+}
+
+type unmarshaler interface {
+	Unmarshal([]byte) error
+}
+
+// codecName is the content-subtype this codec registers under. It
+// deliberately isn't "proto": that name is grpc-go's default codec, used by
+// every RPC that doesn't ask for a different content-subtype, including
+// google.golang.org/grpc/reflection's ServerReflectionInfo call. Clients
+// that want this codec opt in per-call with grpc.CallContentSubtype(codecName)
+// (see fake_service_db_grpc.pb.go); everything else keeps using the real
+// "proto" codec and real protobuf messages.
+const codecName = "fakeservicedb"
+
+// grpcCodec registers itself under codecName so RPCs on the FakeServiceDB
+// service route through it when the client asks for that content-subtype,
+// without affecting any other codec registered under the default name.
+type grpcCodec struct{}
+
+func (grpcCodec) Marshal(v interface{}) ([]byte, error) {
+	m, ok := v.(marshaler)
+	if !ok {
+		return nil, fmt.Errorf("proto: %T does not implement Marshal() ([]byte, error)", v)
+	}
+	return m.Marshal()
+}
+
+func (grpcCodec) Unmarshal(data []byte, v interface{}) error {
+	m, ok := v.(unmarshaler)
+	if !ok {
+		return fmt.Errorf("proto: %T does not implement Unmarshal([]byte) error", v)
+	}
+	return m.Unmarshal(data)
+}
+
+func (grpcCodec) Name() string { return codecName }
+
+func init() {
+	encoding.RegisterCodec(grpcCodec{})
+}
@@ -0,0 +1,200 @@
+// Package proto defines the message types for the FakeServiceDB gRPC
+// service declared in fake_service_db.proto.
+//
+// These types, and their Marshal/Unmarshal methods in this file, are
+// hand-maintained rather than produced by protoc-gen-go: this build
+// environment has neither protoc nor protoc-gen-go available. Marshal and
+// Unmarshal implement the same protobuf wire format (varints and
+// length-delimited fields) a real protoc-generated client would, keeping
+// this package wire-compatible with fake_service_db.proto; see wire.go for
+// the shared low-level encoding and proto/codec.go for the grpc.Codec that
+// calls these methods directly instead of going through reflection. When
+// protoc-gen-go becomes available in a build environment, these files
+// should be regenerated from fake_service_db.proto and this comment removed.
+package proto
+
+// Empty is an intentionally empty request message for the Health and Ready RPCs.
+type Empty struct{}
+
+func (m *Empty) Marshal() ([]byte, error) { return nil, nil }
+
+func (m *Empty) Unmarshal(data []byte) error {
+	*m = Empty{}
+	return nil
+}
+
+// GetCustomersRequest carries headers to propagate to any configured upstreams.
+type GetCustomersRequest struct {
+	Headers map[string]string
+}
+
+func (m *GetCustomersRequest) GetHeaders() map[string]string {
+	if m != nil {
+		return m.Headers
+	}
+	return nil
+}
+
+func (m *GetCustomersRequest) Marshal() ([]byte, error) {
+	var buf []byte
+	buf = appendMapStringString(buf, 1, m.Headers)
+	return buf, nil
+}
+
+func (m *GetCustomersRequest) Unmarshal(data []byte) error {
+	fields, err := parseWireFields(data)
+	if err != nil {
+		return err
+	}
+
+	*m = GetCustomersRequest{}
+
+	for _, f := range fields {
+		if f.num != 1 {
+			continue
+		}
+
+		k, v, err := parseStringMapEntry(f.bytes)
+		if err != nil {
+			return err
+		}
+
+		if m.Headers == nil {
+			m.Headers = map[string]string{}
+		}
+		m.Headers[k] = v
+	}
+
+	return nil
+}
+
+// Response mirrors the JSON Response struct returned by the HTTP handlers,
+// field for field.
+type Response struct {
+	Name          string
+	Uri           string
+	Type          string
+	IpAddresses   []string
+	Path          []string
+	StartTime     string
+	EndTime       string
+	Duration      string
+	Headers       map[string]string
+	Cookies       map[string]string
+	Body          []byte
+	UpstreamCalls map[string]*Response
+	Code          int32
+	Error         string
+}
+
+func (m *Response) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Response) GetUpstreamCalls() map[string]*Response {
+	if m != nil {
+		return m.UpstreamCalls
+	}
+	return nil
+}
+
+func (m *Response) Marshal() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+
+	var buf []byte
+	buf = appendNonEmptyString(buf, 1, m.Name)
+	buf = appendNonEmptyString(buf, 2, m.Uri)
+	buf = appendNonEmptyString(buf, 3, m.Type)
+	buf = appendRepeatedString(buf, 4, m.IpAddresses)
+	buf = appendRepeatedString(buf, 5, m.Path)
+	buf = appendNonEmptyString(buf, 6, m.StartTime)
+	buf = appendNonEmptyString(buf, 7, m.EndTime)
+	buf = appendNonEmptyString(buf, 8, m.Duration)
+	buf = appendMapStringString(buf, 9, m.Headers)
+	buf = appendMapStringString(buf, 10, m.Cookies)
+	buf = appendNonEmptyBytes(buf, 11, m.Body)
+
+	buf, err := appendMapStringMessage(buf, 12, m.UpstreamCalls)
+	if err != nil {
+		return nil, err
+	}
+
+	buf = appendNonZeroInt32(buf, 13, m.Code)
+	buf = appendNonEmptyString(buf, 14, m.Error)
+
+	return buf, nil
+}
+
+func (m *Response) Unmarshal(data []byte) error {
+	fields, err := parseWireFields(data)
+	if err != nil {
+		return err
+	}
+
+	*m = Response{}
+
+	for _, f := range fields {
+		switch f.num {
+		case 1:
+			m.Name = string(f.bytes)
+		case 2:
+			m.Uri = string(f.bytes)
+		case 3:
+			m.Type = string(f.bytes)
+		case 4:
+			m.IpAddresses = append(m.IpAddresses, string(f.bytes))
+		case 5:
+			m.Path = append(m.Path, string(f.bytes))
+		case 6:
+			m.StartTime = string(f.bytes)
+		case 7:
+			m.EndTime = string(f.bytes)
+		case 8:
+			m.Duration = string(f.bytes)
+		case 9:
+			k, v, err := parseStringMapEntry(f.bytes)
+			if err != nil {
+				return err
+			}
+			if m.Headers == nil {
+				m.Headers = map[string]string{}
+			}
+			m.Headers[k] = v
+		case 10:
+			k, v, err := parseStringMapEntry(f.bytes)
+			if err != nil {
+				return err
+			}
+			if m.Cookies == nil {
+				m.Cookies = map[string]string{}
+			}
+			m.Cookies[k] = v
+		case 11:
+			m.Body = append([]byte(nil), f.bytes...)
+		case 12:
+			k, v, err := parseMessageMapEntry(f.bytes)
+			if err != nil {
+				return err
+			}
+			child := &Response{}
+			if err := child.Unmarshal(v); err != nil {
+				return err
+			}
+			if m.UpstreamCalls == nil {
+				m.UpstreamCalls = map[string]*Response{}
+			}
+			m.UpstreamCalls[k] = child
+		case 13:
+			m.Code = int32(f.varint)
+		case 14:
+			m.Error = string(f.bytes)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,165 @@
+// Client/server scaffolding for the FakeServiceDB service declared in
+// fake_service_db.proto. Hand-maintained alongside fake_service_db.pb.go
+// (see that file's doc comment) since protoc-gen-go-grpc isn't available in
+// this build environment; the shape matches what it would generate.
+
+package proto
+
+import (
+	context "context"
+
+	grpc "google.golang.org/grpc"
+)
+
+// FakeServiceDBClient is the client API for FakeServiceDB service.
+type FakeServiceDBClient interface {
+	GetCustomers(ctx context.Context, in *GetCustomersRequest, opts ...grpc.CallOption) (*Response, error)
+	Health(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Response, error)
+	Ready(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Response, error)
+}
+
+type fakeServiceDBClient struct {
+	cc grpc.ClientConnInterface
+}
+
+// NewFakeServiceDBClient builds a FakeServiceDBClient over the given connection.
+func NewFakeServiceDBClient(cc grpc.ClientConnInterface) FakeServiceDBClient {
+	return &fakeServiceDBClient{cc}
+}
+
+// callOpts asks grpc-go to negotiate the fakeservicedb content-subtype for
+// this call, so it's encoded with grpcCodec instead of the default "proto"
+// codec. Per-call opts, if any, are appended after it so a caller can still
+// override it.
+func callOpts(opts []grpc.CallOption) []grpc.CallOption {
+	return append([]grpc.CallOption{grpc.CallContentSubtype(codecName)}, opts...)
+}
+
+func (c *fakeServiceDBClient) GetCustomers(ctx context.Context, in *GetCustomersRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := c.cc.Invoke(ctx, "/fakeservicedb.FakeServiceDB/GetCustomers", in, out, callOpts(opts)...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fakeServiceDBClient) Health(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := c.cc.Invoke(ctx, "/fakeservicedb.FakeServiceDB/Health", in, out, callOpts(opts)...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fakeServiceDBClient) Ready(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := c.cc.Invoke(ctx, "/fakeservicedb.FakeServiceDB/Ready", in, out, callOpts(opts)...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FakeServiceDBServer is the server API for FakeServiceDB service.
+type FakeServiceDBServer interface {
+	GetCustomers(context.Context, *GetCustomersRequest) (*Response, error)
+	Health(context.Context, *Empty) (*Response, error)
+	Ready(context.Context, *Empty) (*Response, error)
+}
+
+// UnimplementedFakeServiceDBServer can be embedded to have forward compatible implementations.
+type UnimplementedFakeServiceDBServer struct{}
+
+func (UnimplementedFakeServiceDBServer) GetCustomers(context.Context, *GetCustomersRequest) (*Response, error) {
+	return nil, grpcNotImplemented("GetCustomers")
+}
+func (UnimplementedFakeServiceDBServer) Health(context.Context, *Empty) (*Response, error) {
+	return nil, grpcNotImplemented("Health")
+}
+func (UnimplementedFakeServiceDBServer) Ready(context.Context, *Empty) (*Response, error) {
+	return nil, grpcNotImplemented("Ready")
+}
+
+func grpcNotImplemented(method string) error {
+	return &notImplementedError{method}
+}
+
+type notImplementedError struct{ method string }
+
+func (e *notImplementedError) Error() string {
+	return "method " + e.method + " not implemented"
+}
+
+// RegisterFakeServiceDBServer registers srv on s using the FakeServiceDB service descriptor.
+func RegisterFakeServiceDBServer(s grpc.ServiceRegistrar, srv FakeServiceDBServer) {
+	s.RegisterService(&FakeServiceDB_ServiceDesc, srv)
+}
+
+func _FakeServiceDB_GetCustomers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCustomersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FakeServiceDBServer).GetCustomers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fakeservicedb.FakeServiceDB/GetCustomers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FakeServiceDBServer).GetCustomers(ctx, req.(*GetCustomersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FakeServiceDB_Health_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FakeServiceDBServer).Health(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fakeservicedb.FakeServiceDB/Health",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FakeServiceDBServer).Health(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FakeServiceDB_Ready_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FakeServiceDBServer).Ready(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fakeservicedb.FakeServiceDB/Ready",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FakeServiceDBServer).Ready(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// FakeServiceDB_ServiceDesc is the grpc.ServiceDesc for FakeServiceDB service.
+var FakeServiceDB_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "fakeservicedb.FakeServiceDB",
+	HandlerType: (*FakeServiceDBServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "GetCustomers", Handler: _FakeServiceDB_GetCustomers_Handler},
+		{MethodName: "Health", Handler: _FakeServiceDB_Health_Handler},
+		{MethodName: "Ready", Handler: _FakeServiceDB_Ready_Handler},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "fake_service_db.proto",
+}
@@ -0,0 +1,231 @@
+package proto
+
+import (
+	"fmt"
+	"sort"
+)
+
+// This file implements just enough of the protobuf binary wire format
+// (varints and length-delimited fields, the only wire types fake_service_db.proto
+// uses) to marshal and unmarshal the generated message types by hand. There
+// is no protoc/protoc-gen-go available in this build environment, so rather
+// than half-implement the google.golang.org/protobuf reflection machinery
+// (which needs a full file descriptor to work correctly and is easy to get
+// subtly wrong), each message marshals itself directly against this wire
+// format, the same format a real protoc-generated client would produce.
+
+const (
+	wireVarint          = 0
+	wireLengthDelimited = 2
+)
+
+func appendTag(buf []byte, fieldNum, wireType int) []byte {
+	return appendVarint(buf, uint64(fieldNum)<<3|uint64(wireType))
+}
+
+func appendVarint(buf []byte, v uint64) []byte {
+	for v >= 0x80 {
+		buf = append(buf, byte(v)|0x80)
+		v >>= 7
+	}
+	return append(buf, byte(v))
+}
+
+func appendLengthDelimited(buf []byte, fieldNum int, data []byte) []byte {
+	buf = appendTag(buf, fieldNum, wireLengthDelimited)
+	buf = appendVarint(buf, uint64(len(data)))
+	return append(buf, data...)
+}
+
+func appendVarintField(buf []byte, fieldNum int, v uint64) []byte {
+	buf = appendTag(buf, fieldNum, wireVarint)
+	return appendVarint(buf, v)
+}
+
+func appendNonEmptyString(buf []byte, fieldNum int, s string) []byte {
+	if s == "" {
+		return buf
+	}
+	return appendLengthDelimited(buf, fieldNum, []byte(s))
+}
+
+func appendRepeatedString(buf []byte, fieldNum int, ss []string) []byte {
+	for _, s := range ss {
+		buf = appendLengthDelimited(buf, fieldNum, []byte(s))
+	}
+	return buf
+}
+
+func appendNonEmptyBytes(buf []byte, fieldNum int, b []byte) []byte {
+	if len(b) == 0 {
+		return buf
+	}
+	return appendLengthDelimited(buf, fieldNum, b)
+}
+
+func appendNonZeroInt32(buf []byte, fieldNum int, v int32) []byte {
+	if v == 0 {
+		return buf
+	}
+	return appendVarintField(buf, fieldNum, uint64(int64(v)))
+}
+
+// appendMapStringString encodes a map[string]string field as a sequence of
+// key/value submessages, the standard protobuf representation for map fields.
+func appendMapStringString(buf []byte, fieldNum int, m map[string]string) []byte {
+	for _, k := range sortedKeys(m) {
+		var entry []byte
+		entry = appendLengthDelimited(entry, 1, []byte(k))
+		entry = appendLengthDelimited(entry, 2, []byte(m[k]))
+		buf = appendLengthDelimited(buf, fieldNum, entry)
+	}
+	return buf
+}
+
+// appendMapStringMessage encodes a map[string]*Response field the same way,
+// with the value submessage itself marshaled via Response.Marshal.
+func appendMapStringMessage(buf []byte, fieldNum int, m map[string]*Response) ([]byte, error) {
+	for _, k := range sortedResponseKeys(m) {
+		val, err := m[k].Marshal()
+		if err != nil {
+			return nil, fmt.Errorf("marshal upstream_calls[%s]: %w", k, err)
+		}
+
+		var entry []byte
+		entry = appendLengthDelimited(entry, 1, []byte(k))
+		entry = appendLengthDelimited(entry, 2, val)
+		buf = appendLengthDelimited(buf, fieldNum, entry)
+	}
+	return buf, nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedResponseKeys(m map[string]*Response) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// wireField is a single decoded (field number, wire type, payload) unit read
+// off the wire, ready to be picked apart by a message's Unmarshal method.
+type wireField struct {
+	num    int
+	varint uint64
+	bytes  []byte
+}
+
+func parseWireFields(data []byte) ([]wireField, error) {
+	var fields []wireField
+
+	for i := 0; i < len(data); {
+		tag, n, err := readVarint(data[i:])
+		if err != nil {
+			return nil, err
+		}
+		i += n
+
+		fieldNum := int(tag >> 3)
+		wireType := int(tag & 0x7)
+
+		switch wireType {
+		case wireVarint:
+			v, n, err := readVarint(data[i:])
+			if err != nil {
+				return nil, err
+			}
+			i += n
+			fields = append(fields, wireField{num: fieldNum, varint: v})
+
+		case wireLengthDelimited:
+			l, n, err := readVarint(data[i:])
+			if err != nil {
+				return nil, err
+			}
+			i += n
+
+			if l > uint64(len(data)-i) {
+				return nil, fmt.Errorf("proto: truncated field %d", fieldNum)
+			}
+
+			fields = append(fields, wireField{num: fieldNum, bytes: data[i : i+int(l)]})
+			i += int(l)
+
+		default:
+			return nil, fmt.Errorf("proto: unsupported wire type %d for field %d", wireType, fieldNum)
+		}
+	}
+
+	return fields, nil
+}
+
+func readVarint(b []byte) (uint64, int, error) {
+	var v uint64
+	var shift uint
+
+	for i := 0; i < len(b); i++ {
+		c := b[i]
+		v |= uint64(c&0x7f) << shift
+		if c < 0x80 {
+			return v, i + 1, nil
+		}
+		shift += 7
+		if shift >= 64 {
+			return 0, 0, fmt.Errorf("proto: varint overflow")
+		}
+	}
+
+	return 0, 0, fmt.Errorf("proto: truncated varint")
+}
+
+// parseStringMapEntry decodes a map<string, string> entry submessage.
+func parseStringMapEntry(data []byte) (string, string, error) {
+	fields, err := parseWireFields(data)
+	if err != nil {
+		return "", "", err
+	}
+
+	var key, value string
+	for _, f := range fields {
+		switch f.num {
+		case 1:
+			key = string(f.bytes)
+		case 2:
+			value = string(f.bytes)
+		}
+	}
+
+	return key, value, nil
+}
+
+// parseMessageMapEntry decodes a map<string, Response> entry submessage,
+// returning the still-encoded value bytes for the caller to unmarshal.
+func parseMessageMapEntry(data []byte) (string, []byte, error) {
+	fields, err := parseWireFields(data)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var key string
+	var value []byte
+	for _, f := range fields {
+		switch f.num {
+		case 1:
+			key = string(f.bytes)
+		case 2:
+			value = f.bytes
+		}
+	}
+
+	return key, value, nil
+}
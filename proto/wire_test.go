@@ -0,0 +1,87 @@
+package proto
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestResponseMarshalUnmarshalRoundTrip(t *testing.T) {
+	in := &Response{
+		Name:        "fake-service-db",
+		Uri:         "http://example.com",
+		Type:        "HTTP",
+		IpAddresses: []string{"10.0.0.1", "10.0.0.2"},
+		Path:        []string{"/customers"},
+		StartTime:   "2026-07-27T00:00:00Z",
+		EndTime:     "2026-07-27T00:00:01Z",
+		Duration:    "1s",
+		Headers:     map[string]string{"X-Request-Id": "abc123"},
+		Cookies:     map[string]string{"session": "xyz"},
+		Body:        []byte(`{"ok":true}`),
+		Code:        200,
+		Error:       "",
+		UpstreamCalls: map[string]*Response{
+			"http://upstream.example.com": {
+				Name:      "upstream",
+				Uri:       "http://upstream.example.com",
+				StartTime: "2026-07-27T00:00:00Z",
+				Code:      200,
+			},
+		},
+	}
+
+	data, err := in.Marshal()
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	out := &Response{}
+	if err := out.Unmarshal(data); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(in, out) {
+		t.Errorf("round-trip mismatch:\n in  = %+v\n out = %+v", in, out)
+	}
+}
+
+func TestGetCustomersRequestMarshalUnmarshalRoundTrip(t *testing.T) {
+	in := &GetCustomersRequest{
+		Headers: map[string]string{"X-Request-Id": "abc123", "Traceparent": "00-abc-def-01"},
+	}
+
+	data, err := in.Marshal()
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	out := &GetCustomersRequest{}
+	if err := out.Unmarshal(data); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(in, out) {
+		t.Errorf("round-trip mismatch:\n in  = %+v\n out = %+v", in, out)
+	}
+}
+
+func TestEmptyMarshalUnmarshal(t *testing.T) {
+	data, err := (&Empty{}).Marshal()
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if len(data) != 0 {
+		t.Errorf("Marshal() = %v, want no bytes for an empty message", data)
+	}
+
+	out := &Empty{}
+	if err := out.Unmarshal(data); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+}
+
+func TestGrpcCodecUsesDistinctContentSubtype(t *testing.T) {
+	if got := (grpcCodec{}).Name(); got == "proto" {
+		t.Errorf("grpcCodec.Name() = %q, must not be %q: that collides with grpc-go's default codec and breaks reflection.Register's ServerReflectionInfo RPC", got, "proto")
+	}
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	hclog "github.com/hashicorp/go-hclog"
+
+	"github.com/nicholasjackson/env"
+)
+
+var queryTable = env.String("QUERY_TABLE", false, "customers", "Table queried by the /rows endpoint")
+var queryColumns = env.String("QUERY_COLUMNS", false, "*", "Comma separated list of columns queried by the /rows endpoint")
+var queryWhere = env.String("QUERY_WHERE", false, "", "Optional WHERE predicate, without the WHERE keyword, appended to the /rows query")
+var queryLimit = env.Int("QUERY_LIMIT", false, 100, "Maximum rows returned by the /rows endpoint, 0 for no limit")
+var querySQL = env.String("QUERY_SQL", false, "", "Full SQL override for the /rows endpoint; when set, QUERY_TABLE/QUERY_COLUMNS/QUERY_WHERE/QUERY_LIMIT are ignored")
+
+var identifierPattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// validateQueryConfig checks the configured table and columns exist in the
+// connected schema via information_schema, so a typo'd QUERY_TABLE or
+// QUERY_COLUMNS surfaces at startup rather than on the first request to
+// /rows. It is a no-op when QUERY_SQL is set, since that query is opaque to us.
+func validateQueryConfig(db *sql.DB) error {
+	if *querySQL != "" {
+		return nil
+	}
+
+	if !identifierPattern.MatchString(*queryTable) {
+		return fmt.Errorf("invalid QUERY_TABLE %q", *queryTable)
+	}
+
+	var exists bool
+	if err := db.QueryRow(
+		"SELECT EXISTS (SELECT 1 FROM information_schema.tables WHERE table_name = $1)",
+		*queryTable,
+	).Scan(&exists); err != nil {
+		return fmt.Errorf("unable to validate QUERY_TABLE: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("table %q does not exist", *queryTable)
+	}
+
+	if strings.TrimSpace(*queryColumns) == "*" {
+		return nil
+	}
+
+	for _, col := range strings.Split(*queryColumns, ",") {
+		col = strings.TrimSpace(col)
+		if !identifierPattern.MatchString(col) {
+			return fmt.Errorf("invalid column %q in QUERY_COLUMNS", col)
+		}
+
+		var colExists bool
+		if err := db.QueryRow(
+			"SELECT EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = $1 AND column_name = $2)",
+			*queryTable, col,
+		).Scan(&colExists); err != nil {
+			return fmt.Errorf("unable to validate column %q: %w", col, err)
+		}
+		if !colExists {
+			return fmt.Errorf("column %q does not exist on table %q", col, *queryTable)
+		}
+	}
+
+	return nil
+}
+
+// buildRowsQuery assembles the SQL statement for /rows from the configured
+// env vars, or returns QUERY_SQL verbatim when set.
+func buildRowsQuery() string {
+	if *querySQL != "" {
+		return *querySQL
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM %s", *queryColumns, *queryTable)
+
+	if strings.TrimSpace(*queryWhere) != "" {
+		query = fmt.Sprintf("%s WHERE %s", query, *queryWhere)
+	}
+
+	if *queryLimit > 0 {
+		query = fmt.Sprintf("%s LIMIT %d", query, *queryLimit)
+	}
+
+	return query
+}
+
+// queryArgsFromRequest maps ?arg1=...&arg2=... query-string parameters to a
+// positional []interface{} for binding to $1, $2, ... placeholders, so
+// demos can show parameterized queries without building SQL by hand.
+func queryArgsFromRequest(r *http.Request) []interface{} {
+	query := r.URL.Query()
+	args := []interface{}{}
+
+	for i := 1; ; i++ {
+		v, ok := query[fmt.Sprintf("arg%d", i)]
+		if !ok {
+			break
+		}
+		args = append(args, v[0])
+	}
+
+	return args
+}
+
+// queryRows runs the configured /rows query and returns each row as a map
+// of column name to value.
+func queryRows(ctx context.Context, db *sql.DB, args []interface{}) ([]map[string]interface{}, error) {
+	query := buildRowsQuery()
+
+	hclog.Default().Info("Querying rows from database", "query", query)
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	results := []map[string]interface{}{}
+
+	for rows.Next() {
+		values := make([]interface{}, len(columns))
+		scanArgs := make([]interface{}, len(columns))
+		for i := range values {
+			scanArgs[i] = &values[i]
+		}
+
+		if err := rows.Scan(scanArgs...); err != nil {
+			return nil, err
+		}
+
+		row := make(map[string]interface{}, len(columns))
+		for i, col := range columns {
+			row[col] = normalizeQueryValue(values[i])
+		}
+
+		results = append(results, row)
+	}
+
+	return results, rows.Err()
+}
+
+// normalizeQueryValue converts driver-returned byte slices (lib/pq's
+// representation for most non-numeric types) into strings so rows
+// serialize cleanly to JSON.
+func normalizeQueryValue(v interface{}) interface{} {
+	if b, ok := v.([]byte); ok {
+		return string(b)
+	}
+	return v
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+const jsonrpcVersion = "2.0"
+
+const (
+	rpcErrParseError     = -32700
+	rpcErrInvalidRequest = -32600
+	rpcErrMethodNotFound = -32601
+	rpcErrInternal       = -32603
+)
+
+var nullID = json.RawMessage("null")
+
+// rpcRequest is a single JSON-RPC 2.0 call within a request body, which may
+// itself be a batch (array) of these.
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	ID      json.RawMessage `json:"id,omitempty"`
+}
+
+type rpcError struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+	ID      json.RawMessage `json:"id"`
+}
+
+// rpc implements a JSON-RPC 2.0 endpoint over customers.list, health.check,
+// and ready.check. customers.list runs buildCustomersResponse, the same domain
+// function as the HTTP "/" route, so both produce the same Response.
+// health.check and ready.check return the buildHealthResponse envelope shared
+// with the gRPC Health and Ready RPCs (ready.check pings via isReady, as the
+// HTTP /ready route does), not the HTTP /health and /ready bodies. Both
+// batch requests (a JSON array of calls) and notifications (calls with no
+// "id") are supported.
+func (a *App) rpc(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		respondWithJSON(w, http.StatusOK, newRPCErrorResponse(nullID, rpcErrParseError, "unable to read request body", nil))
+		return
+	}
+
+	trimmed := bytes.TrimSpace(body)
+
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		var reqs []rpcRequest
+		if err := json.Unmarshal(trimmed, &reqs); err != nil {
+			respondWithJSON(w, http.StatusOK, newRPCErrorResponse(nullID, rpcErrParseError, "invalid batch request", nil))
+			return
+		}
+
+		if len(reqs) == 0 {
+			respondWithJSON(w, http.StatusOK, newRPCErrorResponse(nullID, rpcErrInvalidRequest, "empty batch", nil))
+			return
+		}
+
+		responses := []rpcResponse{}
+		for _, req := range reqs {
+			if resp := a.handleRPCRequest(r, req); resp != nil {
+				responses = append(responses, *resp)
+			}
+		}
+
+		// A batch made up entirely of notifications has no Response objects
+		// to report; per JSON-RPC 2.0 section 6 the server must return
+		// nothing rather than an empty array.
+		if len(responses) == 0 {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		respondWithJSON(w, http.StatusOK, responses)
+		return
+	}
+
+	var req rpcRequest
+	if err := json.Unmarshal(trimmed, &req); err != nil {
+		respondWithJSON(w, http.StatusOK, newRPCErrorResponse(nullID, rpcErrParseError, "invalid request", nil))
+		return
+	}
+
+	resp := a.handleRPCRequest(r, req)
+	if resp == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, resp)
+}
+
+// handleRPCRequest dispatches a single JSON-RPC call to the matching domain
+// function and returns nil for notifications, which get no response.
+func (a *App) handleRPCRequest(r *http.Request, req rpcRequest) *rpcResponse {
+	id := req.ID
+	if len(id) == 0 {
+		id = nullID
+	}
+	isNotification := len(req.ID) == 0
+
+	if req.JSONRPC != jsonrpcVersion || req.Method == "" {
+		if isNotification {
+			return nil
+		}
+		return newRPCErrorResponse(id, rpcErrInvalidRequest, "invalid request", nil)
+	}
+
+	// health.check and ready.check report a DB error as a normal result, the
+	// same contract as the HTTP /health and /ready routes: "not ready" is an
+	// expected state for a readiness poll, not an RPC-level failure. Only
+	// customers.list maps a DB error to the -32603 internal error envelope.
+	switch req.Method {
+	case "customers.list":
+		result := buildCustomersResponse(r.Context(), a.DB, r.Header)
+		if isNotification {
+			return nil
+		}
+		if result.Error != "" {
+			return newRPCErrorResponse(id, rpcErrInternal, "internal error", result.Error)
+		}
+		return &rpcResponse{JSONRPC: jsonrpcVersion, ID: id, Result: result}
+
+	case "health.check":
+		result := buildHealthResponse()
+		if isNotification {
+			return nil
+		}
+		return &rpcResponse{JSONRPC: jsonrpcVersion, ID: id, Result: result}
+
+	case "ready.check":
+		result := buildHealthResponse()
+		if err := a.isReady(r.Context()); err != nil {
+			result.Code = http.StatusServiceUnavailable
+			result.Error = err.Error()
+		}
+		if isNotification {
+			return nil
+		}
+		return &rpcResponse{JSONRPC: jsonrpcVersion, ID: id, Result: result}
+
+	default:
+		if isNotification {
+			return nil
+		}
+		return newRPCErrorResponse(id, rpcErrMethodNotFound, fmt.Sprintf("method %q not found", req.Method), nil)
+	}
+}
+
+func newRPCErrorResponse(id json.RawMessage, code int, message string, data interface{}) *rpcResponse {
+	return &rpcResponse{
+		JSONRPC: jsonrpcVersion,
+		ID:      id,
+		Error:   &rpcError{Code: code, Message: message, Data: data},
+	}
+}
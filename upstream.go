@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nicholasjackson/env"
+)
+
+var upstreamURIs = env.String("UPSTREAM_URIS", false, "", "Comma separated list of upstream URIs to call after fetching customers")
+var upstreamWorkers = env.Int("UPSTREAM_WORKERS", false, 4, "Maximum number of concurrent upstream calls")
+var upstreamTimeout = env.Duration("UPSTREAM_TIMEOUT", false, 3*time.Second, "Timeout for a single upstream call")
+
+// propagatedHeaders is the set of incoming headers forwarded to upstream calls,
+// covering request correlation and the common distributed tracing formats.
+var propagatedHeaders = []string{
+	"X-Request-ID",
+	"X-B3-Traceid",
+	"X-B3-Spanid",
+	"X-B3-Parentspanid",
+	"X-B3-Sampled",
+	"X-B3-Flags",
+	"X-Ot-Span-Context",
+	"Traceparent",
+	"Tracestate",
+}
+
+// parseUpstreamURIs splits a comma separated UPSTREAM_URIS value into a clean
+// list of URIs, ignoring empty entries.
+func parseUpstreamURIs(raw string) []string {
+	uris := []string{}
+
+	for _, uri := range strings.Split(raw, ",") {
+		uri = strings.TrimSpace(uri)
+		if uri != "" {
+			uris = append(uris, uri)
+		}
+	}
+
+	return uris
+}
+
+// callUpstreams fans out a GET request to every URI in uris, bounded by workers
+// concurrent requests, and returns each result keyed by the URI it was fetched from.
+// A transport error for a given upstream is recorded on that entry's Response.Error
+// rather than failing the other calls.
+func callUpstreams(ctx context.Context, uris []string, workers int, timeout time.Duration, headers http.Header) map[string]Response {
+	results := make(map[string]Response, len(uris))
+
+	if len(uris) == 0 {
+		return results
+	}
+
+	if workers < 1 {
+		workers = 1
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	sem := make(chan struct{}, workers)
+
+	for _, uri := range uris {
+		uri := uri
+
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			resp := callUpstream(ctx, uri, timeout, headers)
+
+			mu.Lock()
+			results[uri] = resp
+			mu.Unlock()
+		}()
+	}
+
+	wg.Wait()
+
+	return results
+}
+
+// callUpstream performs a single GET request to uri, recording timing fields on
+// the returned Response regardless of success, and parsing the upstream's JSON
+// body via Response.FromJSON when the call succeeds.
+func callUpstream(ctx context.Context, uri string, timeout time.Duration, headers http.Header) (resp Response) {
+	resp = Response{URI: uri}
+
+	ts := time.Now()
+	startTime := ts.Format(timeFormat)
+	resp.StartTime = startTime
+
+	defer func() {
+		te := time.Now()
+		resp.EndTime = te.Format(timeFormat)
+		resp.Duration = te.Sub(ts).String()
+	}()
+
+	reqCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodGet, uri, nil)
+	if err != nil {
+		resp.Error = err.Error()
+		return resp
+	}
+
+	for _, h := range propagatedHeaders {
+		if v := headers.Get(h); v != "" {
+			req.Header.Set(h, v)
+		}
+	}
+
+	httpResp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		resp.Error = err.Error()
+		return resp
+	}
+	defer httpResp.Body.Close()
+
+	resp.Code = httpResp.StatusCode
+
+	body, err := ioutil.ReadAll(httpResp.Body)
+	if err != nil {
+		resp.Error = fmt.Sprintf("unable to read upstream body: %s", err)
+		return resp
+	}
+
+	if err := resp.FromJSON(body); err != nil {
+		resp.Error = fmt.Sprintf("unable to parse upstream response: %s", err)
+	}
+
+	// FromJSON replaces the whole struct with the upstream's self-reported
+	// Response, so the fields that describe *this* call rather than the
+	// upstream itself need to be restored.
+	resp.URI = uri
+	resp.StartTime = startTime
+	resp.Code = httpResp.StatusCode
+
+	return resp
+}
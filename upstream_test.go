@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCallUpstreamPreservesCallerTimingAndURI(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"name":"downstream","uri":"self-reported","start_time":"child-start","code":200}`))
+	}))
+	defer srv.Close()
+
+	resp := callUpstream(context.Background(), srv.URL, time.Second, http.Header{})
+
+	if resp.URI != srv.URL {
+		t.Errorf("URI = %q, want the called URI %q, not the upstream's self-reported uri", resp.URI, srv.URL)
+	}
+	if resp.StartTime == "" || resp.StartTime == "child-start" {
+		t.Errorf("StartTime = %q, want the caller's own start time, not the upstream's self-reported value", resp.StartTime)
+	}
+	if resp.EndTime == "" {
+		t.Error("EndTime is empty, want it set after the call completes")
+	}
+	if resp.Duration == "" {
+		t.Error("Duration is empty, want it set after the call completes")
+	}
+	if resp.Code != http.StatusOK {
+		t.Errorf("Code = %d, want %d", resp.Code, http.StatusOK)
+	}
+}
+
+func TestCallUpstreamTransportError(t *testing.T) {
+	resp := callUpstream(context.Background(), "http://127.0.0.1:0", 50*time.Millisecond, http.Header{})
+
+	if resp.Error == "" {
+		t.Error("Error is empty, want a transport error recorded instead of failing the whole request")
+	}
+	if resp.EndTime == "" || resp.Duration == "" {
+		t.Error("EndTime/Duration should still be set even when the call fails")
+	}
+}